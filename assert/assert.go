@@ -98,6 +98,7 @@ func assert(
 	comparison BoolOrComparison,
 	msgAndArgs ...interface{},
 ) bool {
+	t = wrapForReporting(t)
 	if ht, ok := t.(helperT); ok {
 		ht.Helper()
 	}
@@ -146,15 +147,21 @@ func runCompareFunc(
 }
 
 func logFailureFromBool(t TestingT, msgAndArgs ...interface{}) {
-	const stackIndex = 3 // Assert()/Check(), assert(), formatFailureFromBool()
+	const stackIndex = 3 // Assert()/Check(), assert(), logFailureFromBool()
 	const comparisonArgPos = 1
-	source, err := source.FormattedCallExprArg(stackIndex, comparisonArgPos)
+	argPos := comparisonArgPos
+	inner := t
+	if rt, ok := t.(*reportingT); ok {
+		inner = rt.TestingT
+	}
+	if _, ok := inner.(*groupT); ok {
+		argPos = 0 // Group.Assert/Check take no t, so the comparison comes first
+	}
+	expr, err := source.FormattedCallExprArg(stackIndex, argPos)
 	if err != nil {
 		t.Log(err.Error())
 	}
-
-	msg := " is false"
-	t.Log(format.WithCustomMessage(failureMessage+source+msg, msgAndArgs...))
+	reportFailure(t, expr, "is false", msgAndArgs...)
 }
 
 // Assert performs a comparison, marks the test as having failed if the comparison
@@ -0,0 +1,165 @@
+package assert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+// Assertion is a chainable assertion returned by That. Each method performs
+// a comparison and records the original source expression, the same way
+// Assert(t, ...) does, so failure messages still show the caller's
+// expression rather than "That(...)".
+//
+// By default a failed method ends the test immediately, as with Assert;
+// call Should to switch to Check semantics (the chain continues after
+// recording the failure) and Must to switch back.
+type Assertion struct {
+	t          TestingT
+	value      interface{}
+	failer     func()
+	pendingMsg []interface{}
+}
+
+// That returns a chainable Assertion for value.
+func That(t TestingT, value interface{}) *Assertion {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	return &Assertion{t: t, value: value, failer: t.FailNow}
+}
+
+// Must switches the chain to Assert semantics (t.FailNow on failure). This
+// is the default; Must is only needed to switch back after a prior Should.
+func (a *Assertion) Must() *Assertion {
+	a.failer = a.t.FailNow
+	return a
+}
+
+// Should switches the chain to Check semantics (t.Fail on failure, so the
+// chain continues and later methods still run).
+func (a *Assertion) Should() *Assertion {
+	a.failer = a.t.Fail
+	return a
+}
+
+// WithMessage attaches a custom failure message to the next comparison in
+// the chain.
+func (a *Assertion) WithMessage(msgAndArgs ...interface{}) *Assertion {
+	a.pendingMsg = msgAndArgs
+	return a
+}
+
+func (a *Assertion) takeMessage() []interface{} {
+	msg := a.pendingMsg
+	a.pendingMsg = nil
+	return msg
+}
+
+// Equals uses the == operator to compare the chain's value to y.
+func (a *Assertion) Equals(y interface{}) *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(a.t, a.failer, filterExprArgsFromComparison, cmp.Equal(a.value, y), a.takeMessage()...)
+	return a
+}
+
+// DeepEquals uses google/go-cmp to compare the chain's value to y.
+func (a *Assertion) DeepEquals(y interface{}) *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(a.t, a.failer, filterExprArgsFromComparison, cmp.DeepEqual(a.value, y), a.takeMessage()...)
+	return a
+}
+
+// Contains asserts that the chain's value (a string, map, slice, or array)
+// contains x.
+func (a *Assertion) Contains(x interface{}) *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(a.t, a.failer, filterExprArgsFromComparison, cmp.Contains(a.value, x), a.takeMessage()...)
+	return a
+}
+
+// Len asserts that the chain's value has length n.
+func (a *Assertion) Len(n int) *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(a.t, a.failer, filterExprArgsFromComparison, cmp.Len(a.value, n), a.takeMessage()...)
+	return a
+}
+
+// Matches asserts that the chain's value, which must be a string, matches
+// the regular expression pattern.
+func (a *Assertion) Matches(pattern string) *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	comparison := func() cmp.Result {
+		str, ok := a.value.(string)
+		if !ok {
+			return cmp.ResultFailure(fmt.Sprintf("Matches requires a string, got %T", a.value))
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return cmp.ResultFromError(err)
+		}
+		if re.MatchString(str) {
+			return cmp.ResultSuccess()
+		}
+		return cmp.ResultFailure(fmt.Sprintf("%q does not match pattern %q", str, pattern))
+	}
+	assert(a.t, a.failer, filterExprArgsFromComparison, comparison, a.takeMessage()...)
+	return a
+}
+
+// IsNil asserts that the chain's value is nil.
+func (a *Assertion) IsNil() *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(a.t, a.failer, filterExprArgsFromComparison, cmp.Nil(a.value), a.takeMessage()...)
+	return a
+}
+
+// IsError asserts that the chain's value is a non-nil error for which
+// errors.Is(value, target) is true.
+func (a *Assertion) IsError(target error) *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	comparison := func() cmp.Result {
+		err, ok := a.value.(error)
+		if !ok {
+			return cmp.ResultFailure(fmt.Sprintf("IsError requires an error, got %T", a.value))
+		}
+		if errors.Is(err, target) {
+			return cmp.ResultSuccess()
+		}
+		return cmp.ResultFailure(fmt.Sprintf("error %v does not match target %v", err, target))
+	}
+	assert(a.t, a.failer, filterExprArgsFromComparison, comparison, a.takeMessage()...)
+	return a
+}
+
+// Eventually polls fn every interval, up to timeout, until it succeeds. On
+// failure the message includes the history of intermediate results, the
+// same as the package-level Eventually.
+func (a *Assertion) Eventually(fn func() cmp.Result, timeout, interval time.Duration) *Assertion {
+	if ht, ok := a.t.(helperT); ok {
+		ht.Helper()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, history := pollEventually(ctx, fn, interval)
+	assert(a.t, a.failer, filterExprArgsFromComparison, pollComparison(res, history), a.takeMessage()...)
+	return a
+}
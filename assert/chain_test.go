@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestThat_mustIsTheDefault(t *testing.T) {
+	ft := &fakeT{}
+	That(ft, 1).Equals(2)
+
+	if !ft.failedNow || ft.failed {
+		t.Fatalf("expected FailNow semantics by default, got failedNow=%v failed=%v", ft.failedNow, ft.failed)
+	}
+}
+
+func TestThat_should(t *testing.T) {
+	ft := &fakeT{}
+	That(ft, 1).Should().Equals(2)
+
+	if !ft.failed || ft.failedNow {
+		t.Fatalf("expected Check semantics after Should, got failed=%v failedNow=%v", ft.failed, ft.failedNow)
+	}
+}
+
+func TestThat_mustSwitchesBackAfterShould(t *testing.T) {
+	ft := &fakeT{}
+	That(ft, 1).Should().Must().Equals(2)
+
+	if !ft.failedNow {
+		t.Fatal("expected Must to switch the chain back to FailNow semantics")
+	}
+}
+
+func TestThat_chainContinuesUnderShouldSemantics(t *testing.T) {
+	ft := &fakeT{}
+	That(ft, 1).Should().Equals(2).Equals(3)
+
+	if len(ft.logs) != 2 {
+		t.Fatalf("expected both failing comparisons to run and log, got %d: %v", len(ft.logs), ft.logs)
+	}
+}
+
+func TestThat_failureMessageUsesCallersExpression(t *testing.T) {
+	ft := &fakeT{}
+	x := 1
+	That(ft, x).Equals(2)
+
+	if len(ft.logs) != 1 {
+		t.Fatalf("expected 1 logged failure, got %d: %v", len(ft.logs), ft.logs)
+	}
+	if !strings.Contains(ft.logs[0], "x") {
+		t.Fatalf("expected the failure message to reference the caller's expression %q, got %q", "x", ft.logs[0])
+	}
+	if strings.Contains(ft.logs[0], "a.value") {
+		t.Fatalf("failure message leaked chain.go's own variable name instead of the caller's: %q", ft.logs[0])
+	}
+}
+
+func TestThat_withMessageAppliesOnlyToNextComparison(t *testing.T) {
+	ft := &fakeT{}
+	a := That(ft, 1).Should()
+	a.WithMessage("custom message").Equals(2)
+	a.Equals(3)
+
+	if len(ft.logs) != 2 {
+		t.Fatalf("expected 2 logged failures, got %d", len(ft.logs))
+	}
+	if !strings.Contains(ft.logs[0], "custom message") {
+		t.Fatalf("expected the first failure to include the custom message, got %q", ft.logs[0])
+	}
+	if strings.Contains(ft.logs[1], "custom message") {
+		t.Fatalf("did not expect the custom message to carry over to the second failure, got %q", ft.logs[1])
+	}
+}
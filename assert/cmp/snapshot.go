@@ -0,0 +1,127 @@
+package cmp
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+// SnapshotEncoder converts a value into the bytes that are written to, and
+// later compared against, a snapshot file. Register one with
+// RegisterSnapshotEncoder to control how a particular type is serialized,
+// for values (protobuf messages, HTTP responses, etc.) whose default
+// formatting is not deterministic.
+type SnapshotEncoder func(value interface{}) ([]byte, error)
+
+var (
+	snapshotEncodersMu sync.RWMutex
+	snapshotEncoders   = map[reflect.Type]SnapshotEncoder{}
+)
+
+// RegisterSnapshotEncoder registers enc as the encoder used by Snapshot
+// whenever the value being snapshotted has the same type as sample. Tests
+// commonly register encoders and take snapshots concurrently under
+// t.Parallel(), so access to the registry is guarded by a mutex, the same
+// as the built-in Reporters guard their writes.
+func RegisterSnapshotEncoder(sample interface{}, enc SnapshotEncoder) {
+	snapshotEncodersMu.Lock()
+	defer snapshotEncodersMu.Unlock()
+	snapshotEncoders[reflect.TypeOf(sample)] = enc
+}
+
+// defaultSnapshotEncoder produces a deterministic JSON dump: encoding/json
+// already sorts map keys and preserves struct field declaration order, so
+// no custom encoder is required for the common case.
+func defaultSnapshotEncoder(value interface{}) ([]byte, error) {
+	raw, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return append(raw, '\n'), nil
+}
+
+func encodeSnapshot(value interface{}) ([]byte, error) {
+	snapshotEncodersMu.RLock()
+	enc, ok := snapshotEncoders[reflect.TypeOf(value)]
+	snapshotEncodersMu.RUnlock()
+	if ok {
+		return enc(value)
+	}
+	return defaultSnapshotEncoder(value)
+}
+
+// shouldUpdateSnapshot reports whether golden/snapshot files should be
+// (re)written instead of compared. golden.Assert already registers the
+// -test.update-golden flag; registering it a second time here would panic
+// ("flag redefined") in any test binary that links both packages, so this
+// looks the existing flag up instead of declaring its own. Projects that
+// only use assert.Snapshot (and never import golden) can still opt in with
+// GOTESTTOOLS_UPDATE_SNAPSHOT=1.
+func shouldUpdateSnapshot() bool {
+	if os.Getenv("GOTESTTOOLS_UPDATE_SNAPSHOT") == "1" {
+		return true
+	}
+	if f := flag.Lookup("test.update-golden"); f != nil {
+		return f.Value.String() == "true"
+	}
+	return false
+}
+
+func snapshotPath(testName, name string) string {
+	return filepath.Join("testdata", "snapshots", testName, name+".golden")
+}
+
+// Snapshot compares value, serialized with the default encoder (or a
+// per-type encoder registered with RegisterSnapshotEncoder), to the
+// contents of testdata/snapshots/<testName>/<name>.golden, failing with a
+// unified diff on mismatch. When -test.update-golden or
+// GOTESTTOOLS_UPDATE_SNAPSHOT=1 is set, the file (and any missing parent
+// directories) is created or overwritten instead of compared.
+func Snapshot(testName string, value interface{}, name string) Comparison {
+	return func() Result {
+		actual, err := encodeSnapshot(value)
+		if err != nil {
+			return ResultFromError(err)
+		}
+		path := snapshotPath(testName, name)
+
+		if shouldUpdateSnapshot() {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return ResultFromError(err)
+			}
+			if err := ioutil.WriteFile(path, actual, 0o644); err != nil {
+				return ResultFromError(err)
+			}
+			return ResultSuccess()
+		}
+
+		expected, err := ioutil.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			return ResultFailure(fmt.Sprintf(
+				"snapshot %s does not exist, run with -test.update-golden or GOTESTTOOLS_UPDATE_SNAPSHOT=1 to create it",
+				path))
+		case err != nil:
+			return ResultFromError(err)
+		case bytes.Equal(expected, actual):
+			return ResultSuccess()
+		}
+		return ResultFailure(fmt.Sprintf("snapshot %s does not match\n%s",
+			path, unifiedDiff(string(expected), string(actual))))
+	}
+}
+
+// unifiedDiff renders a line-level diff between expected and actual using
+// go-cmp, the same diff renderer DeepEqual uses, instead of a bespoke one.
+func unifiedDiff(expected, actual string) string {
+	return gocmp.Diff(strings.Split(expected, "\n"), strings.Split(actual, "\n"))
+}
@@ -0,0 +1,100 @@
+package cmp
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSnapshot_createThenMatch(t *testing.T) {
+	testName := t.Name()
+	name := "greeting"
+	defer os.RemoveAll(filepath.Join("testdata", "snapshots", testName))
+
+	value := map[string]interface{}{"greeting": "hello", "count": 2}
+
+	os.Setenv("GOTESTTOOLS_UPDATE_SNAPSHOT", "1")
+	if res := Snapshot(testName, value, name)(); !res.Success() {
+		t.Fatal("failed to create the snapshot file")
+	}
+	os.Unsetenv("GOTESTTOOLS_UPDATE_SNAPSHOT")
+
+	if _, err := os.Stat(snapshotPath(testName, name)); err != nil {
+		t.Fatalf("snapshot file was not created: %v", err)
+	}
+
+	if res := Snapshot(testName, value, name)(); !res.Success() {
+		t.Fatal("snapshot comparison failed against the value used to create it")
+	}
+}
+
+func TestSnapshot_mismatchFails(t *testing.T) {
+	testName := t.Name()
+	name := "greeting"
+	defer os.RemoveAll(filepath.Join("testdata", "snapshots", testName))
+
+	os.Setenv("GOTESTTOOLS_UPDATE_SNAPSHOT", "1")
+	Snapshot(testName, map[string]interface{}{"greeting": "hello"}, name)()
+	os.Unsetenv("GOTESTTOOLS_UPDATE_SNAPSHOT")
+
+	if res := Snapshot(testName, map[string]interface{}{"greeting": "bye"}, name)(); res.Success() {
+		t.Fatal("expected a mismatched snapshot to fail")
+	}
+}
+
+func TestSnapshot_missingFileFails(t *testing.T) {
+	testName := t.Name()
+	defer os.RemoveAll(filepath.Join("testdata", "snapshots", testName))
+
+	if res := Snapshot(testName, "anything", "never-created")(); res.Success() {
+		t.Fatal("expected a missing snapshot file to fail")
+	}
+}
+
+func TestRegisterSnapshotEncoder_usedInsteadOfDefault(t *testing.T) {
+	type customType struct{ Value string }
+	defer delete(snapshotEncoders, reflect.TypeOf(customType{}))
+
+	RegisterSnapshotEncoder(customType{}, func(value interface{}) ([]byte, error) {
+		return []byte("custom:" + value.(customType).Value), nil
+	})
+
+	testName := t.Name()
+	defer os.RemoveAll(filepath.Join("testdata", "snapshots", testName))
+
+	os.Setenv("GOTESTTOOLS_UPDATE_SNAPSHOT", "1")
+	Snapshot(testName, customType{Value: "x"}, "custom")()
+	os.Unsetenv("GOTESTTOOLS_UPDATE_SNAPSHOT")
+
+	raw, err := ioutil.ReadFile(snapshotPath(testName, "custom"))
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if string(raw) != "custom:x" {
+		t.Fatalf("got %q, want the custom encoder's output", raw)
+	}
+}
+
+func TestRegisterSnapshotEncoder_concurrentAccessDoesNotRace(t *testing.T) {
+	type customType struct{ Value string }
+	defer delete(snapshotEncoders, reflect.TypeOf(customType{}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterSnapshotEncoder(customType{}, func(value interface{}) ([]byte, error) {
+				return []byte(value.(customType).Value), nil
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = encodeSnapshot(customType{Value: "x"})
+		}()
+	}
+	wg.Wait()
+}
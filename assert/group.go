@@ -0,0 +1,138 @@
+package assert
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+// Group collects assertion failures instead of failing the test
+// immediately, so one test run can report every broken invariant instead of
+// stopping at the first one. Create one with NewGroup and call Report
+// (directly, or automatically through t.Cleanup) once all checks have run.
+//
+// A Group's plain text log messages are batched until Report, but an
+// installed Reporter still sees each failure as it happens rather than
+// batched with the others: Group wraps its proxy TestingT the same way any
+// other TestingT is wrapped, so the Reporter's own view of failures stays
+// real-time even though t.Log doesn't.
+type Group struct {
+	t      TestingT
+	proxy  *groupT
+	mu     sync.Mutex
+	msgs   []string
+	failed bool
+}
+
+// cleanupT is implemented by *testing.T; when t provides it, NewGroup
+// registers Report to run automatically at the end of the test.
+type cleanupT interface {
+	Cleanup(func())
+}
+
+// NewGroup returns a Group that collects failures reported through its
+// Equal, NilError, Assert, and Check methods instead of failing t
+// immediately.
+func NewGroup(t TestingT) *Group {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	g := &Group{t: t}
+	g.proxy = &groupT{g: g}
+	if ct, ok := t.(cleanupT); ok {
+		ct.Cleanup(g.Report)
+	}
+	return g
+}
+
+// Assert is the grouped equivalent of the package-level Assert. The
+// comparison never stops the group early (the underlying t.FailNow would
+// abort the whole test, not just this check), so it is recorded as a Check.
+func (g *Group) Assert(comparison BoolOrComparison, msgAndArgs ...interface{}) {
+	if ht, ok := g.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(g.proxy, g.proxy.Fail, filterExprArgsFromComparison, comparison, msgAndArgs...)
+}
+
+// Check is the grouped equivalent of the package-level Check.
+func (g *Group) Check(comparison BoolOrComparison, msgAndArgs ...interface{}) bool {
+	if ht, ok := g.t.(helperT); ok {
+		ht.Helper()
+	}
+	return assert(g.proxy, g.proxy.Fail, filterExprArgsFromComparison, comparison, msgAndArgs...)
+}
+
+// Equal is the grouped equivalent of the package-level Equal.
+func (g *Group) Equal(x, y interface{}, msgAndArgs ...interface{}) {
+	if ht, ok := g.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(g.proxy, g.proxy.Fail, filterExprExcludeFirst, cmp.Equal(x, y), msgAndArgs...)
+}
+
+// NilError is the grouped equivalent of the package-level NilError.
+func (g *Group) NilError(err error, msgAndArgs ...interface{}) {
+	if ht, ok := g.t.(helperT); ok {
+		ht.Helper()
+	}
+	assert(g.proxy, g.proxy.Fail, filterExprExcludeFirst, cmp.NilError(err), msgAndArgs...)
+}
+
+// Report fails the underlying test and writes every collected message, if
+// any check in the group failed since the last Report. It is safe to call
+// more than once (including automatically, via t.Cleanup) and is a no-op
+// when nothing is pending.
+func (g *Group) Report() {
+	if ht, ok := g.t.(helperT); ok {
+		ht.Helper()
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.failed {
+		return
+	}
+	for _, msg := range g.msgs {
+		g.t.Log(msg)
+	}
+	g.t.Fail()
+	g.failed = false
+	g.msgs = nil
+}
+
+// groupT adapts a Group to satisfy TestingT for the shared assert() helper:
+// Fail/FailNow record the failure against the group instead of touching the
+// real t directly, and Log buffers the message until Report is called.
+type groupT struct {
+	g *Group
+}
+
+func (p *groupT) Fail()    { p.g.recordFailure() }
+func (p *groupT) FailNow() { p.g.recordFailure() }
+func (p *groupT) Log(args ...interface{}) {
+	p.g.mu.Lock()
+	defer p.g.mu.Unlock()
+	p.g.msgs = append(p.g.msgs, fmt.Sprint(args...))
+}
+func (p *groupT) Helper() {
+	if ht, ok := p.g.t.(helperT); ok {
+		ht.Helper()
+	}
+}
+
+// Name forwards the real t's name, when it has one, so a Reporter sees the
+// actual running test instead of an empty TestName for every grouped
+// check.
+func (p *groupT) Name() string {
+	if nt, ok := p.g.t.(namerT); ok {
+		return nt.Name()
+	}
+	return ""
+}
+
+func (g *Group) recordFailure() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failed = true
+}
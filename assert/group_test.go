@@ -0,0 +1,101 @@
+package assert
+
+import "testing"
+
+// fakeCleanupT is a fakeT that also implements cleanupT.
+type fakeCleanupT struct {
+	*fakeT
+	cleanups []func()
+}
+
+func (f *fakeCleanupT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func TestGroup_collectsFailuresUntilReport(t *testing.T) {
+	ft := &fakeT{}
+	g := NewGroup(ft)
+
+	g.Equal(1, 2)
+	g.Equal(3, 3)
+	g.Check(false)
+
+	if ft.failed {
+		t.Fatal("did not expect the underlying test to fail before Report")
+	}
+
+	g.Report()
+
+	if !ft.failed {
+		t.Fatal("expected Report to fail the underlying test")
+	}
+	if len(ft.logs) != 2 {
+		t.Fatalf("expected 2 failure messages (Equal(1,2) and Check(false)), got %d: %v", len(ft.logs), ft.logs)
+	}
+}
+
+func TestGroup_reportIsANoopWhenNothingFailed(t *testing.T) {
+	ft := &fakeT{}
+	g := NewGroup(ft)
+
+	g.Equal(1, 1)
+	g.Report()
+
+	if ft.failed || len(ft.logs) != 0 {
+		t.Fatalf("expected nothing to be recorded, got failed=%v logs=%v", ft.failed, ft.logs)
+	}
+}
+
+func TestGroup_reportDoesNotRepeatOnceCleared(t *testing.T) {
+	ft := &fakeT{}
+	g := NewGroup(ft)
+
+	g.Equal(1, 2)
+	g.Report()
+	ft.failed = false // simulate a fresh check from the test's perspective
+
+	g.Report()
+	if ft.failed {
+		t.Fatal("expected the second Report to be a no-op since nothing failed since the first")
+	}
+}
+
+// fakeNamedT is a fakeT that also implements namerT, like *testing.T.
+type fakeNamedT struct {
+	*fakeT
+	name string
+}
+
+func (f *fakeNamedT) Name() string { return f.name }
+
+func TestGroup_forwardsTheRealTsNameToTheReporter(t *testing.T) {
+	reporter := &fakeReporter{}
+	SetReporter(reporter)
+	defer SetReporter(nil)
+
+	nt := &fakeNamedT{fakeT: &fakeT{}, name: "TestSomething"}
+	g := NewGroup(nt)
+	g.Equal(1, 2)
+
+	if len(reporter.failures) != 1 {
+		t.Fatalf("expected 1 reported failure, got %d", len(reporter.failures))
+	}
+	if got := reporter.failures[0].TestName; got != "TestSomething" {
+		t.Fatalf("expected TestName %q forwarded from the real t, got %q", "TestSomething", got)
+	}
+}
+
+func TestGroup_reportRunsAutomaticallyViaCleanup(t *testing.T) {
+	ft := &fakeCleanupT{fakeT: &fakeT{}}
+	g := NewGroup(ft)
+	g.Equal(1, 2)
+
+	if len(ft.cleanups) != 1 {
+		t.Fatalf("expected NewGroup to register exactly one Cleanup func, got %d", len(ft.cleanups))
+	}
+
+	ft.cleanups[0]()
+	if !ft.failed {
+		t.Fatal("expected the registered cleanup to call Report and fail the test")
+	}
+}
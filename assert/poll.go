@@ -0,0 +1,141 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+// Eventually polls cond every interval, up to timeout, until it succeeds,
+// then asserts on the outcome. On failure the message includes the history
+// of intermediate results (up to maxPollHistory of them), so a flaky
+// condition's near-misses aren't lost.
+func Eventually(
+	t TestingT, cond func() cmp.Result, timeout, interval time.Duration, msgAndArgs ...interface{},
+) {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	EventuallyContext(ctx, t, cond, interval, msgAndArgs...)
+}
+
+// Consistently polls cond every interval for the full duration of timeout
+// and fails as soon as cond stops succeeding (or never succeeded at all).
+func Consistently(
+	t TestingT, cond func() cmp.Result, timeout, interval time.Duration, msgAndArgs ...interface{},
+) {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ConsistentlyContext(ctx, t, cond, interval, msgAndArgs...)
+}
+
+// EventuallyContext is like Eventually, but stops polling early, as a
+// failure, when ctx is done instead of relying on a fixed timeout.
+func EventuallyContext(
+	ctx context.Context, t TestingT, cond func() cmp.Result, interval time.Duration, msgAndArgs ...interface{},
+) {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	res, history := pollEventually(ctx, cond, interval)
+	assert(t, t.FailNow, filterExprExcludeFirst, pollComparison(res, history), msgAndArgs...)
+}
+
+// ConsistentlyContext is like Consistently, but polls until ctx is done
+// instead of for a fixed timeout; cond holding until then is a success.
+func ConsistentlyContext(
+	ctx context.Context, t TestingT, cond func() cmp.Result, interval time.Duration, msgAndArgs ...interface{},
+) {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	res, history := pollConsistently(ctx, cond, interval)
+	assert(t, t.FailNow, filterExprExcludeFirst, pollComparison(res, history), msgAndArgs...)
+}
+
+const maxPollHistory = 5
+
+type pollObservation struct {
+	at     time.Duration
+	result cmp.Result
+}
+
+func pollEventually(ctx context.Context, cond func() cmp.Result, interval time.Duration) (cmp.Result, []pollObservation) {
+	start := time.Now()
+	var history []pollObservation
+	for {
+		res := cond()
+		history = appendObservation(history, time.Since(start), res)
+		if res.Success() {
+			return res, history
+		}
+		select {
+		case <-ctx.Done():
+			return res, history
+		case <-time.After(interval):
+		}
+	}
+}
+
+func pollConsistently(ctx context.Context, cond func() cmp.Result, interval time.Duration) (cmp.Result, []pollObservation) {
+	start := time.Now()
+	var history []pollObservation
+	var last cmp.Result
+	for {
+		res := cond()
+		history = appendObservation(history, time.Since(start), res)
+		last = res
+		if !res.Success() {
+			return res, history
+		}
+		select {
+		case <-ctx.Done():
+			return last, history
+		case <-time.After(interval):
+		}
+	}
+}
+
+func appendObservation(history []pollObservation, at time.Duration, res cmp.Result) []pollObservation {
+	history = append(history, pollObservation{at: at, result: res})
+	if len(history) > maxPollHistory {
+		history = history[len(history)-maxPollHistory:]
+	}
+	return history
+}
+
+func pollComparison(res cmp.Result, history []pollObservation) cmp.Comparison {
+	return func() cmp.Result {
+		if res.Success() {
+			return cmp.ResultSuccess()
+		}
+		return cmp.ResultFailure(formatPollHistory(history))
+	}
+}
+
+func formatPollHistory(history []pollObservation) string {
+	var b strings.Builder
+	b.WriteString("condition did not hold; recent results:\n")
+	for _, obs := range history {
+		b.WriteString(fmt.Sprintf("  [%s] %s\n", obs.at.Round(time.Millisecond), resultMessage(obs.result)))
+	}
+	return b.String()
+}
+
+func resultMessage(r cmp.Result) string {
+	if r.Success() {
+		return "ok"
+	}
+	if f, ok := r.(interface{ FailureMessage() string }); ok {
+		return f.FailureMessage()
+	}
+	return "failed"
+}
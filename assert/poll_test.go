@@ -0,0 +1,123 @@
+package assert
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gotestyourself/gotestyourself/assert/cmp"
+)
+
+func TestEventually_succeedsOnceConditionPasses(t *testing.T) {
+	ft := &fakeT{}
+	attempts := 0
+	cond := func() cmp.Result {
+		attempts++
+		if attempts >= 3 {
+			return cmp.ResultSuccess()
+		}
+		return cmp.ResultFailure("not yet")
+	}
+
+	Eventually(ft, cond, time.Second, time.Millisecond)
+
+	if ft.failedNow {
+		t.Fatalf("expected Eventually to succeed once cond passes, got logs %v", ft.logs)
+	}
+	if attempts < 3 {
+		t.Fatalf("expected at least 3 attempts before success, got %d", attempts)
+	}
+}
+
+func TestEventually_failsWhenConditionNeverSucceeds(t *testing.T) {
+	ft := &fakeT{}
+	cond := func() cmp.Result { return cmp.ResultFailure("nope") }
+
+	Eventually(ft, cond, 5*time.Millisecond, time.Millisecond)
+
+	if !ft.failedNow {
+		t.Fatal("expected Eventually to fail when cond never succeeds before the timeout")
+	}
+	if len(ft.logs) != 1 || !strings.Contains(ft.logs[0], "nope") {
+		t.Fatalf("expected the failure message to include the last observed result, got %v", ft.logs)
+	}
+}
+
+func TestConsistently_failsAsSoonAsConditionStopsSucceeding(t *testing.T) {
+	ft := &fakeT{}
+	attempts := 0
+	cond := func() cmp.Result {
+		attempts++
+		if attempts == 2 {
+			return cmp.ResultFailure("broke on attempt 2")
+		}
+		return cmp.ResultSuccess()
+	}
+
+	Consistently(ft, cond, time.Second, time.Millisecond)
+
+	if !ft.failedNow {
+		t.Fatal("expected Consistently to fail as soon as cond stopped succeeding")
+	}
+	if attempts != 2 {
+		t.Fatalf("expected Consistently to stop polling right after the failure, got %d attempts", attempts)
+	}
+}
+
+func TestEventuallyContext_stopsWhenContextIsAlreadyDone(t *testing.T) {
+	ft := &fakeT{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cond := func() cmp.Result { return cmp.ResultFailure("still failing") }
+
+	done := make(chan struct{})
+	go func() {
+		EventuallyContext(ctx, ft, cond, time.Hour)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("EventuallyContext did not stop promptly once ctx was done")
+	}
+	if !ft.failedNow {
+		t.Fatal("expected EventuallyContext to fail when the condition never succeeds before ctx is done")
+	}
+}
+
+func TestConsistentlyContext_stopsWhenContextIsCancelled(t *testing.T) {
+	ft := &fakeT{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cond := func() cmp.Result { return cmp.ResultSuccess() }
+
+	done := make(chan struct{})
+	go func() {
+		ConsistentlyContext(ctx, ft, cond, time.Millisecond)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ConsistentlyContext did not stop after ctx was cancelled")
+	}
+	if ft.failedNow {
+		t.Fatalf("expected ConsistentlyContext to succeed when cond holds until ctx is cancelled, got logs %v", ft.logs)
+	}
+}
+
+func TestAppendObservation_truncatesToMaxPollHistory(t *testing.T) {
+	var history []pollObservation
+	for i := 0; i < maxPollHistory+3; i++ {
+		history = appendObservation(history, time.Duration(i)*time.Millisecond, cmp.ResultFailure("x"))
+	}
+	if len(history) != maxPollHistory {
+		t.Fatalf("expected history capped at %d entries, got %d", maxPollHistory, len(history))
+	}
+}
@@ -0,0 +1,259 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gotestyourself/gotestyourself/internal/format"
+)
+
+// FailureContext carries the information passed to a Reporter when an
+// assertion fails, as separate fields rather than one pre-rendered line, so
+// a CI system can consume the test name, expression, and diff directly
+// instead of having to scrape them back out of log text.
+type FailureContext struct {
+	// TestName is the name of the currently running test, when t provides
+	// one (see namerT).
+	TestName string
+	// Expr is the literal source text of a failing plain bool expression,
+	// when FormattedCallExprArg could recover it. It is empty for every
+	// other failure.
+	Expr string
+	// Diff is the explanatory detail for the failure: "is false" for the
+	// plain bool form, or the whole rendered failure line for every other
+	// failure (Comparison results included), which reaches the Reporter
+	// through reportingT.Log.
+	Diff string
+	// Message is the caller-supplied msgAndArgs, rendered; empty if the
+	// caller didn't pass one.
+	Message string
+}
+
+// renderedLine reconstructs the single-line form of ctx, the same text a
+// plain-text Reporter (TextReporter, TAPReporter) showed before
+// FailureContext exposed Expr/Diff/Message separately. reportingT.Log's
+// fallback already puts a fully rendered (and prefixed) line in Diff, so
+// that case is detected by the prefix and used as-is instead of prefixing
+// it a second time; Expr alone (which can legitimately be empty, e.g. when
+// FormattedCallExprArg failed to recover it) isn't a reliable signal.
+func (ctx FailureContext) renderedLine() string {
+	line := ctx.Diff
+	if !strings.HasPrefix(line, failureMessage) {
+		line = failureMessage + ctx.Expr + " " + ctx.Diff
+	}
+	if ctx.Message != "" {
+		line += ": " + ctx.Message
+	}
+	return line
+}
+
+// Reporter receives structured failure information instead of (or in
+// addition to) the plain text logged through TestingT.Log. A TestingT that
+// also implements Reporter has every assertion failure routed through
+// ReportFailure. SetReporter installs a Reporter for TestingT values that
+// don't implement it themselves.
+type Reporter interface {
+	ReportFailure(ctx FailureContext)
+}
+
+var defaultReporter Reporter
+
+// SetReporter installs r as the Reporter used for any TestingT that does
+// not itself implement Reporter. Pass nil to restore the default behavior
+// of only logging through TestingT.Log. The GOTESTTOOLS_REPORTER env var
+// ("json" or "tap") selects a built-in reporter writing to os.Stdout at
+// import time; a later call to SetReporter overrides it.
+func SetReporter(r Reporter) {
+	defaultReporter = r
+}
+
+func init() {
+	switch os.Getenv("GOTESTTOOLS_REPORTER") {
+	case "json":
+		defaultReporter = NewJSONReporter(os.Stdout)
+	case "tap":
+		defaultReporter = NewTAPReporter(os.Stdout)
+	}
+}
+
+func selectReporter(t TestingT) Reporter {
+	if p, ok := t.(*groupT); ok {
+		t = p.g.t
+	}
+	if r, ok := t.(Reporter); ok {
+		return r
+	}
+	return defaultReporter
+}
+
+// wrapForReporting returns a TestingT that forwards every call to t, except
+// that any message logged is also delivered to the selected Reporter (if
+// any) as a FailureContext.
+func wrapForReporting(t TestingT) TestingT {
+	if _, ok := t.(*reportingT); ok {
+		return t
+	}
+	reporter := selectReporter(t)
+	if reporter == nil {
+		return t
+	}
+	testName := ""
+	if nt, ok := t.(namerT); ok {
+		testName = nt.Name()
+	}
+	return &reportingT{TestingT: t, reporter: reporter, testName: testName}
+}
+
+type reportingT struct {
+	TestingT
+	reporter Reporter
+	testName string
+}
+
+func (r *reportingT) Helper() {
+	if ht, ok := r.TestingT.(helperT); ok {
+		ht.Helper()
+	}
+}
+
+// Log is the fallback path for a message that isn't already routed through
+// reportFailure with structured fields (the legacy func() (bool, string)
+// comparison form, or any other direct TestingT.Log call made through this
+// wrapper): the whole rendered line is reported as Diff, since there's no
+// separate expression to recover here.
+func (r *reportingT) Log(args ...interface{}) {
+	r.TestingT.Log(args...)
+	r.reporter.ReportFailure(FailureContext{
+		TestName: r.testName,
+		Diff:     fmt.Sprint(args...),
+	})
+}
+
+// reportFailure renders the standard failure line (the same text
+// previously produced by the caller) and, when t is wrapped for reporting,
+// also delivers expr/diff/message as structured fields on FailureContext
+// instead of making the Reporter re-parse the rendered line. It logs
+// directly through the unwrapped TestingT so the structured report isn't
+// duplicated by Log's own interception.
+func reportFailure(t TestingT, expr, diff string, msgAndArgs ...interface{}) {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	text := format.WithCustomMessage(failureMessage+expr+" "+diff, msgAndArgs...)
+
+	rt, ok := t.(*reportingT)
+	if !ok {
+		t.Log(text)
+		return
+	}
+	rt.TestingT.Log(text)
+	rt.reporter.ReportFailure(FailureContext{
+		TestName: rt.testName,
+		Expr:     expr,
+		Diff:     diff,
+		Message:  renderMessage(msgAndArgs...),
+	})
+}
+
+// renderMessage renders just the caller-supplied msgAndArgs, the same way
+// Assert/Check interpret them: a leading format string followed by its
+// Sprintf args, or (with a single element, or a non-string first element)
+// plain fmt.Sprint. This necessarily duplicates format.WithCustomMessage's
+// msgAndArgs handling rather than reusing it, since that function only
+// returns the combined base-message-plus-custom-message line, with no way
+// to recover the custom message on its own for FailureContext.Message.
+func renderMessage(msgAndArgs ...interface{}) string {
+	switch len(msgAndArgs) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprint(msgAndArgs[0])
+	default:
+		if f, ok := msgAndArgs[0].(string); ok {
+			return fmt.Sprintf(f, msgAndArgs[1:]...)
+		}
+		return fmt.Sprint(msgAndArgs...)
+	}
+}
+
+// TextReporter writes one line per failure, the same text that would
+// otherwise go to TestingT.Log. It exists so the default behavior can be
+// selected explicitly, e.g. to override a Reporter set elsewhere.
+//
+// A single Reporter is commonly shared process-wide (via SetReporter or
+// GOTESTTOOLS_REPORTER) across tests running with t.Parallel(), so every
+// built-in Reporter guards its writes with a mutex.
+type TextReporter struct {
+	Out io.Writer
+	mu  sync.Mutex
+}
+
+// NewTextReporter returns a TextReporter that writes to out.
+func NewTextReporter(out io.Writer) *TextReporter {
+	return &TextReporter{Out: out}
+}
+
+// ReportFailure implements Reporter.
+func (r *TextReporter) ReportFailure(ctx FailureContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintln(r.Out, ctx.renderedLine())
+}
+
+// JSONReporter writes one JSON object per line (JSON Lines) describing each
+// assertion failure, so CI systems can machine-parse failures instead of
+// scraping log output.
+type JSONReporter struct {
+	Out io.Writer
+	mu  sync.Mutex
+}
+
+// NewJSONReporter returns a JSONReporter that writes to out.
+func NewJSONReporter(out io.Writer) *JSONReporter {
+	return &JSONReporter{Out: out}
+}
+
+type jsonFailure struct {
+	Test    string `json:"test,omitempty"`
+	Expr    string `json:"expr,omitempty"`
+	Diff    string `json:"diff,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ReportFailure implements Reporter.
+func (r *JSONReporter) ReportFailure(ctx FailureContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = json.NewEncoder(r.Out).Encode(jsonFailure{
+		Test:    ctx.TestName,
+		Expr:    ctx.Expr,
+		Diff:    ctx.Diff,
+		Message: ctx.Message,
+	})
+}
+
+// TAPReporter writes each failure as a TAP (Test Anything Protocol)
+// "not ok" line with the message as a diagnostic.
+type TAPReporter struct {
+	Out   io.Writer
+	mu    sync.Mutex
+	count int
+}
+
+// NewTAPReporter returns a TAPReporter that writes to out.
+func NewTAPReporter(out io.Writer) *TAPReporter {
+	return &TAPReporter{Out: out}
+}
+
+// ReportFailure implements Reporter.
+func (r *TAPReporter) ReportFailure(ctx FailureContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.count++
+	fmt.Fprintf(r.Out, "not ok %d - %s\n", r.count, ctx.TestName)
+	fmt.Fprintf(r.Out, "  ---\n  message: %q\n  ...\n", ctx.renderedLine())
+}
@@ -0,0 +1,119 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+// fakeT is a minimal TestingT used across this package's tests.
+type fakeT struct {
+	failed    bool
+	failedNow bool
+	logs      []string
+}
+
+func (f *fakeT) Fail()    { f.failed = true }
+func (f *fakeT) FailNow() { f.failedNow = true }
+func (f *fakeT) Log(args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprint(args...))
+}
+
+// fakeReporter records every FailureContext it is given.
+type fakeReporter struct {
+	failures []FailureContext
+}
+
+func (r *fakeReporter) ReportFailure(ctx FailureContext) {
+	r.failures = append(r.failures, ctx)
+}
+
+// fakeReporterT is a fakeT that also implements Reporter itself.
+type fakeReporterT struct {
+	*fakeT
+	reporter *fakeReporter
+}
+
+func (f *fakeReporterT) ReportFailure(ctx FailureContext) {
+	f.reporter.ReportFailure(ctx)
+}
+
+func TestReporter_routesFailuresWhenTestingTImplementsIt(t *testing.T) {
+	reporter := &fakeReporter{}
+	ft := &fakeReporterT{fakeT: &fakeT{}, reporter: reporter}
+
+	Check(ft, false)
+
+	if len(reporter.failures) != 1 {
+		t.Fatalf("expected 1 reported failure, got %d", len(reporter.failures))
+	}
+	if !ft.failed {
+		t.Fatal("expected the underlying Check failure to still be recorded")
+	}
+}
+
+func TestReporter_carriesStructuredFieldsNotJustARenderedLine(t *testing.T) {
+	reporter := &fakeReporter{}
+	ft := &fakeReporterT{fakeT: &fakeT{}, reporter: reporter}
+
+	ok := false
+	Check(ft, ok, "custom message")
+
+	if len(reporter.failures) != 1 {
+		t.Fatalf("expected 1 reported failure, got %d", len(reporter.failures))
+	}
+	ctx := reporter.failures[0]
+	if ctx.Expr != "ok" {
+		t.Fatalf("expected Expr to be the caller's expression %q, got %q", "ok", ctx.Expr)
+	}
+	if ctx.Diff != "is false" {
+		t.Fatalf("expected Diff to describe the failure, got %q", ctx.Diff)
+	}
+	if ctx.Message != "custom message" {
+		t.Fatalf("expected Message to carry only the caller's custom message, got %q", ctx.Message)
+	}
+}
+
+func TestSetReporter_usedAsDefaultForPlainTestingT(t *testing.T) {
+	reporter := &fakeReporter{}
+	SetReporter(reporter)
+	defer SetReporter(nil)
+
+	ft := &fakeT{}
+	Check(ft, false)
+
+	if len(reporter.failures) != 1 {
+		t.Fatalf("expected 1 reported failure, got %d", len(reporter.failures))
+	}
+}
+
+func TestSetReporter_doesNotOverrideTestingTsOwnReporter(t *testing.T) {
+	global := &fakeReporter{}
+	own := &fakeReporter{}
+	SetReporter(global)
+	defer SetReporter(nil)
+
+	ft := &fakeReporterT{fakeT: &fakeT{}, reporter: own}
+	Check(ft, false)
+
+	if len(own.failures) != 1 {
+		t.Fatalf("expected the TestingT's own Reporter to receive the failure, got %d", len(own.failures))
+	}
+	if len(global.failures) != 0 {
+		t.Fatalf("expected the global Reporter to be skipped, got %d failures", len(global.failures))
+	}
+}
+
+func TestSetReporter_nilRestoresPlainLogging(t *testing.T) {
+	SetReporter(&fakeReporter{})
+	SetReporter(nil)
+
+	ft := &fakeT{}
+	Check(ft, false)
+
+	if !ft.failed {
+		t.Fatal("expected Check to still fail without a Reporter installed")
+	}
+	if len(ft.logs) != 1 {
+		t.Fatalf("expected 1 logged failure, got %d", len(ft.logs))
+	}
+}
@@ -0,0 +1,32 @@
+package assert
+
+import "github.com/gotestyourself/gotestyourself/assert/cmp"
+
+// namerT is implemented by *testing.T; it is used to derive the directory a
+// snapshot is stored under without requiring every TestingT to provide it.
+type namerT interface {
+	Name() string
+}
+
+// RegisterSnapshotEncoder registers enc as the encoder used by Snapshot
+// whenever the value being snapshotted has the same type as sample. See
+// cmp.RegisterSnapshotEncoder.
+func RegisterSnapshotEncoder(sample interface{}, enc cmp.SnapshotEncoder) {
+	cmp.RegisterSnapshotEncoder(sample, enc)
+}
+
+// Snapshot compares value to a golden file recorded under
+// testdata/snapshots/<TestName>/<name>.golden and fails the test
+// immediately if they differ. Run the test with -test.update-golden (or set
+// GOTESTTOOLS_UPDATE_SNAPSHOT=1) to create or refresh the file. This is
+// equivalent to Assert(t, cmp.Snapshot(t.Name(), value, name)).
+func Snapshot(t TestingT, value interface{}, name string, msgAndArgs ...interface{}) {
+	if ht, ok := t.(helperT); ok {
+		ht.Helper()
+	}
+	testName := "unknown"
+	if nt, ok := t.(namerT); ok {
+		testName = nt.Name()
+	}
+	assert(t, t.FailNow, filterExprExcludeFirst, cmp.Snapshot(testName, value, name), msgAndArgs...)
+}
@@ -0,0 +1,185 @@
+// Package source provides utilities for reading the source around a call
+// site, used to recover the literal text of an argument expression for
+// assertion failure messages.
+package source
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// FormattedCallExprArg returns the argIndex argument of the function call
+// running stackIndex frames up the stack (0 is the caller of
+// FormattedCallExprArg), formatted as source text.
+//
+// file+line is not always enough to identify the call: "foo(); bar(x)" puts
+// two unrelated calls on one line, and "bar(x); bar(y)" puts two calls to
+// the *same* function on one line. FormattedCallExprArg resolves the first
+// case by matching each candidate call expression's function selector
+// against the name of the function that was actually invoked from that
+// line — not the name of the function the line itself belongs to, which is
+// the enclosing test or helper, never the thing being called. The second
+// case can't be told apart by name or by line number at all (the runtime
+// does not expose column information), so calls sharing both a line and a
+// function name are disambiguated by the order they are encountered at
+// runtime, which matches their left-to-right textual order for ordinary
+// sequential execution.
+func FormattedCallExprArg(stackIndex, argIndex int) (string, error) {
+	target, callee, ok := framesAt(stackIndex)
+	if !ok {
+		return "", fmt.Errorf("failed to get the frame at stack index %d", stackIndex)
+	}
+
+	callExpr, err := findCallExpr(target, callee)
+	if err != nil {
+		return "", err
+	}
+	if argIndex >= len(callExpr.Args) {
+		return "", fmt.Errorf("index %d out of range for args %v", argIndex, callExpr.Args)
+	}
+	return formatNode(callExpr.Args[argIndex])
+}
+
+// framesAt returns two consecutive frames counted from the caller of
+// FormattedCallExprArg: target, the frame stackIndex levels up, where the
+// call site being inspected lives; and callee, the frame directly below
+// target — the function that line actually called, and therefore the name
+// the candidate call expressions on that line must be matched against.
+// Using runtime.CallersFrames (rather than runtime.FuncForPC per PC)
+// accounts for inlined calls correctly.
+func framesAt(stackIndex int) (target, callee runtime.Frame, ok bool) {
+	pc := make([]uintptr, stackIndex+4)
+	n := runtime.Callers(2, pc) // skip runtime.Callers and framesAt; pc[0] = FormattedCallExprArg
+	if n == 0 {
+		return runtime.Frame{}, runtime.Frame{}, false
+	}
+	frames := runtime.CallersFrames(pc[:n])
+	for i := 0; ; i++ {
+		frame, more := frames.Next()
+		switch i {
+		case stackIndex:
+			callee = frame
+		case stackIndex + 1:
+			return frame, callee, true
+		}
+		if !more {
+			return runtime.Frame{}, runtime.Frame{}, false
+		}
+	}
+}
+
+func findCallExpr(target, callee runtime.Frame) (*ast.CallExpr, error) {
+	fileSet := token.NewFileSet()
+	astFile, err := parseFile(fileSet, target.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file %s: %w", target.File, err)
+	}
+
+	candidates := callExprsOnLine(astFile, fileSet, target.Line)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("failed to find a call expression on %s:%d", target.File, target.Line)
+	}
+	return disambiguate(candidates, callee.Function, target)
+}
+
+func parseFile(fileSet *token.FileSet, path string) (*ast.File, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parser.ParseFile(fileSet, path, raw, 0)
+}
+
+// callExprsOnLine returns every CallExpr in astFile whose opening
+// parenthesis is on line, in source order.
+func callExprsOnLine(astFile *ast.File, fileSet *token.FileSet, line int) []*ast.CallExpr {
+	var found []*ast.CallExpr
+	ast.Inspect(astFile, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && fileSet.Position(call.Lparen).Line == line {
+			found = append(found, call)
+		}
+		return true
+	})
+	return found
+}
+
+// disambiguate picks the call expression, among candidates (all on the same
+// line, in target), whose selector matches calleeFunc, the fully qualified
+// name of the function that was actually invoked from that line.
+func disambiguate(candidates []*ast.CallExpr, calleeFunc string, target runtime.Frame) (*ast.CallExpr, error) {
+	short := shortFuncName(calleeFunc)
+
+	var matched []*ast.CallExpr
+	for _, call := range candidates {
+		if selectorName(call.Fun) == short {
+			matched = append(matched, call)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("failed to find a call to %s on %s:%d", short, target.File, target.Line)
+	case 1:
+		return matched[0], nil
+	}
+
+	// Several calls to the same function share this line (e.g.
+	// "assert.Check(a); assert.Check(b)"). They necessarily run one at a
+	// time and in their textual order, so a per-line call counter that
+	// cycles through the candidates in source order picks the one that is
+	// actually executing.
+	key := fmt.Sprintf("%s:%d:%s", target.File, target.Line, short)
+	return matched[nextOccurrence(key, len(matched))], nil
+}
+
+var occurrenceCounters sync.Map // map[string]*uint64
+
+func nextOccurrence(key string, numCandidates int) int {
+	v, _ := occurrenceCounters.LoadOrStore(key, new(uint64))
+	counter := v.(*uint64)
+	n := atomic.AddUint64(counter, 1) - 1
+	return int(n % uint64(numCandidates))
+}
+
+// shortFuncName strips the package path and (for a method or a method
+// value such as "T.Method-fm") the receiver, leaving the bare function or
+// method name, e.g. "github.com/x/y/assert.Check" -> "Check". That is the
+// identifier at a direct call site; a call through a func or method value
+// uses the value's own name instead, which does not match.
+func shortFuncName(funcName string) string {
+	if idx := strings.LastIndexByte(funcName, '/'); idx >= 0 {
+		funcName = funcName[idx+1:]
+	}
+	funcName = strings.TrimSuffix(funcName, "-fm")
+	if idx := strings.LastIndexByte(funcName, '.'); idx >= 0 {
+		funcName = funcName[idx+1:]
+	}
+	return funcName
+}
+
+func selectorName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	default:
+		return ""
+	}
+}
+
+func formatNode(n ast.Node) (string, error) {
+	buf := new(strings.Builder)
+	if err := printer.Fprint(buf, token.NewFileSet(), n); err != nil {
+		return "", fmt.Errorf("failed to format expression: %w", err)
+	}
+	return buf.String(), nil
+}
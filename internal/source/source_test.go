@@ -0,0 +1,47 @@
+package source
+
+import "testing"
+
+func doNothing() {}
+
+// stackIndex 0 is the caller of FormattedCallExprArg, which for these
+// tests is the test function's own call site.
+const testStackIndex = 0
+
+func TestFormattedCallExprArg_ignoresOtherCallOnSameLine(t *testing.T) {
+	// gofmt: keep these calls on one line — this is the disambiguation
+	// scenario under test ("foo(); bar(x)" on a single source line).
+	doNothing(); got, err := FormattedCallExprArg(testStackIndex, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "testStackIndex"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormattedCallExprArg_disambiguatesRepeatedCallOnSameLine(t *testing.T) {
+	// gofmt: keep these calls on one line — this is the disambiguation
+	// scenario under test (two calls to the *same* function sharing a
+	// line, e.g. "bar(x); bar(y)").
+	first, err1 := FormattedCallExprArg(testStackIndex, 0); second, err2 := FormattedCallExprArg(testStackIndex, 1)
+	if err1 != nil || err2 != nil {
+		t.Fatalf("unexpected error: %v, %v", err1, err2)
+	}
+	if first != "testStackIndex" || second != "1" {
+		t.Fatalf("got %q, %q", first, second)
+	}
+}
+
+// A call reached through a method/func value (rather than a direct
+// "pkg.Func(...)" selector) can't be matched by name against the running
+// frame's reported function, since the value's name at the call site bears
+// no relation to the function it holds. FormattedCallExprArg returns an
+// error in this case instead of silently picking the wrong candidate.
+func TestFormattedCallExprArg_methodValueCallTarget(t *testing.T) {
+	fn := FormattedCallExprArg
+	if _, err := fn(testStackIndex, 0); err == nil {
+		t.Fatal("expected an error disambiguating a func value call target, got none")
+	}
+}